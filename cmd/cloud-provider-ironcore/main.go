@@ -1,21 +1,9 @@
-// Copyright 2022 OnMetal authors
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//      http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
 
 package main
 
 import (
-	"github.com/onmetal/cloud-provider-onmetal/pkg/cloudprovider/onmetal"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/wait"
 	cloudprovider "k8s.io/cloud-provider"
@@ -28,6 +16,8 @@ import (
 	_ "k8s.io/component-base/metrics/prometheus/clientgo"
 	_ "k8s.io/component-base/metrics/prometheus/version"
 	"k8s.io/klog/v2"
+
+	"github.com/opensovereigncloud/cloud-provider-ironcore/pkg/cloudprovider/ironcore"
 )
 
 func main() {
@@ -42,7 +32,7 @@ func main() {
 	controllerInitializers := app.DefaultInitFuncConstructors
 	namedFlagSets := cliflag.NamedFlagSets{}
 
-	onmetal.AddExtraFlags(pflag.CommandLine)
+	ironcore.AddExtraFlags(pflag.CommandLine)
 
 	controllerAliases := names.CCMControllerAliases()
 
@@ -58,7 +48,7 @@ func cloudInitializer(config *cloudcontrollerconfig.CompletedConfig) cloudprovid
 	providerName := cloudConfig.Name
 
 	if providerName == "" {
-		providerName = onmetal.ProviderName
+		providerName = ironcore.ProviderName
 	}
 
 	// initialize cloud provider with the cloud provider name and config file provided
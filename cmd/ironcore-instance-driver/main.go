@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command ironcore-instance-driver is the reference implementation of the driver.v1.InstanceDriver
+// gRPC service. It resolves instances directly against the ironcore API, exactly like the CCM
+// does by default in-process, and exists so out-of-tree driver authors have a known-good
+// conformance partner to test --instance-driver-endpoint wiring against.
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	computev1alpha1 "github.com/ironcore-dev/ironcore/api/compute/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+	"github.com/opensovereigncloud/cloud-provider-ironcore/pkg/cloudprovider/ironcore"
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+func main() {
+	var (
+		cloudConfigFile string
+		listenEndpoint  string
+	)
+	flag.StringVar(&cloudConfigFile, "cloud-config", "", "Path to the ironcore cloud-config file (namespace, networkName, clusterName).")
+	flag.StringVar(&listenEndpoint, "listen-endpoint", "unix:///var/run/ironcore-instance-driver.sock", "Endpoint to serve the InstanceDriver gRPC service on.")
+	flag.Parse()
+
+	cfg, err := readCloudConfig(cloudConfigFile)
+	if err != nil {
+		klog.Fatalf("unable to read cloud config: %v", err)
+	}
+
+	if err := computev1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		klog.Fatalf("unable to add compute/v1alpha1 to scheme: %v", err)
+	}
+	if err := networkingv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		klog.Fatalf("unable to add networking/v1alpha1 to scheme: %v", err)
+	}
+
+	ironcoreClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		klog.Fatalf("unable to create ironcore client: %v", err)
+	}
+
+	network, protocolAddr, err := parseEndpoint(listenEndpoint)
+	if err != nil {
+		klog.Fatalf("invalid --listen-endpoint %q: %v", listenEndpoint, err)
+	}
+
+	listener, err := net.Listen(network, protocolAddr)
+	if err != nil {
+		klog.Fatalf("unable to listen on %s: %v", listenEndpoint, err)
+	}
+
+	server := grpc.NewServer()
+	driverv1.RegisterInstanceDriverServer(server, ironcore.NewInstanceDriverServer(ironcoreClient, cfg))
+
+	klog.Infof("ironcore-instance-driver listening on %s", listenEndpoint)
+	if err := server.Serve(listener); err != nil {
+		klog.Fatalf("instance driver server stopped: %v", err)
+	}
+}
+
+func readCloudConfig(path string) (ironcore.CloudConfig, error) {
+	var cfg ironcore.CloudConfig
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// parseEndpoint splits a "unix:///path/to.sock" or "tcp://host:port" endpoint into the pair
+// expected by net.Listen.
+func parseEndpoint(endpoint string) (network, address string, err error) {
+	const unixPrefix = "unix://"
+	const tcpPrefix = "tcp://"
+	switch {
+	case len(endpoint) > len(unixPrefix) && endpoint[:len(unixPrefix)] == unixPrefix:
+		return "unix", endpoint[len(unixPrefix):], nil
+	case len(endpoint) > len(tcpPrefix) && endpoint[:len(tcpPrefix)] == tcpPrefix:
+		return "tcp", endpoint[len(tcpPrefix):], nil
+	default:
+		return "tcp", endpoint, nil
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	computev1alpha1 "github.com/ironcore-dev/ironcore/api/compute/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+	"github.com/opensovereigncloud/cloud-provider-ironcore/pkg/cloudprovider/ironcore/metadata"
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+func getProviderID(namespace, machineName string) string {
+	return fmt.Sprintf("%s://%s/%s", ProviderName, namespace, machineName)
+}
+
+// instanceDriver resolves the instance backing a Node. It is satisfied by the built-in
+// ironcoreDriver as well as by grpcDriver, which dials an out-of-tree --instance-driver-endpoint.
+type instanceDriver interface {
+	GetInstance(ctx context.Context, name string) (*driverv1.GetInstanceResponse, error)
+}
+
+// ironcoreDriver is the default instanceDriver, resolving instances directly against the
+// ironcore API via a controller-runtime client.
+type ironcoreDriver struct {
+	ironcoreClient       client.Client
+	namespace            string
+	machinePoolNamespace string
+	clusterName          string
+	defaultAddressType   corev1.NodeAddressType
+	topologyLabelPrefix  string
+
+	// metadataClient, if set, is queried as a fallback source of instance metadata for Nodes that
+	// have no matching Machine object (e.g. self-registered kubelets on hosts provisioned outside
+	// the ironcore API).
+	metadataClient *metadata.Client
+}
+
+var _ instanceDriver = (*ironcoreDriver)(nil)
+
+func (d *ironcoreDriver) GetInstance(ctx context.Context, name string) (*driverv1.GetInstanceResponse, error) {
+	machine := &computev1alpha1.Machine{}
+	if err := d.ironcoreClient.Get(ctx, types.NamespacedName{Namespace: d.namespace, Name: name}, machine); err != nil {
+		if apierrors.IsNotFound(err) {
+			if d.metadataClient != nil {
+				return d.getInstanceFromMetadataService(ctx, name)
+			}
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+
+	if err := d.ensureClusterNameLabel(ctx, machine); err != nil {
+		return nil, fmt.Errorf("failed to label machine %s: %w", client.ObjectKeyFromObject(machine), err)
+	}
+
+	nodeAddresses, err := d.nodeAddressesForMachine(ctx, machine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine node addresses for machine %s: %w", client.ObjectKeyFromObject(machine), err)
+	}
+
+	var instanceType string
+	if machine.Spec.MachineClassRef != nil {
+		instanceType = machine.Spec.MachineClassRef.Name
+	}
+
+	zone, region, err := d.zoneAndRegionForMachine(ctx, machine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine zone/region for machine %s: %w", client.ObjectKeyFromObject(machine), err)
+	}
+
+	return &driverv1.GetInstanceResponse{
+		ProviderId:    getProviderID(machine.Namespace, machine.Name),
+		InstanceType:  instanceType,
+		NodeAddresses: nodeAddresses,
+		Zone:          zone,
+		Region:        region,
+		Shutdown:      machine.Status.State == computev1alpha1.MachineStateShutdown,
+	}, nil
+}
+
+// getInstanceFromMetadataService falls back to d.metadataClient for a Node with no matching
+// Machine object, translating metadata.ErrNotFound into the same gRPC NotFound status the
+// ironcore-backed lookup returns so callers don't need to special-case the fallback.
+func (d *ironcoreDriver) getInstanceFromMetadataService(ctx context.Context, name string) (*driverv1.GetInstanceResponse, error) {
+	instance, err := d.metadataClient.GetInstance(ctx, name)
+	if err != nil {
+		if errors.Is(err, metadata.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get instance %s from metadata service: %w", name, err)
+	}
+	return instance, nil
+}
+
+// nodeAddressesForMachine resolves the addresses for every network interface attached to the
+// given machine. IPs on a NetworkInterface are classified by the address-type annotation of the
+// Network they belong to (AnnotationKeyAddressType), falling back to d.defaultAddressType.
+func (d *ironcoreDriver) nodeAddressesForMachine(ctx context.Context, machine *computev1alpha1.Machine) ([]*driverv1.NodeAddress, error) {
+	var nodeAddresses []*driverv1.NodeAddress
+	for _, nicStatus := range machine.Status.NetworkInterfaces {
+		nic := &networkingv1alpha1.NetworkInterface{}
+		if err := d.ironcoreClient.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: nicStatus.NetworkInterfaceRef.Name}, nic); err != nil {
+			return nil, err
+		}
+
+		if err := d.ensureClusterNameLabel(ctx, nic); err != nil {
+			return nil, fmt.Errorf("failed to label network interface %s: %w", client.ObjectKeyFromObject(nic), err)
+		}
+
+		addressType, err := d.addressTypeForNetworkInterface(ctx, nic)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range nic.Status.IPs {
+			nodeAddresses = append(nodeAddresses, &driverv1.NodeAddress{
+				Type:    string(addressType),
+				Address: ip.String(),
+			})
+		}
+		if nic.Status.VirtualIP != nil {
+			nodeAddresses = append(nodeAddresses, &driverv1.NodeAddress{
+				Type:    string(corev1.NodeExternalIP),
+				Address: nic.Status.VirtualIP.String(),
+			})
+		}
+	}
+
+	return nodeAddresses, nil
+}
+
+// addressTypeForNetworkInterface looks up the Network nic is attached to and returns the
+// NodeAddressType requested via AnnotationKeyAddressType, falling back to d.defaultAddressType
+// if the Network has no such annotation.
+func (d *ironcoreDriver) addressTypeForNetworkInterface(ctx context.Context, nic *networkingv1alpha1.NetworkInterface) (corev1.NodeAddressType, error) {
+	network := &networkingv1alpha1.Network{}
+	if err := d.ironcoreClient.Get(ctx, types.NamespacedName{Namespace: nic.Namespace, Name: nic.Spec.NetworkRef.Name}, network); err != nil {
+		if apierrors.IsNotFound(err) {
+			return d.defaultAddressType, nil
+		}
+		return "", err
+	}
+
+	if addressType, ok := network.Annotations[AnnotationKeyAddressType]; ok {
+		return corev1.NodeAddressType(addressType), nil
+	}
+	return d.defaultAddressType, nil
+}
+
+// zoneAndRegionForMachine resolves the topology of the MachinePool the given machine is
+// scheduled to, preferring the well-known topology.kubernetes.io/{zone,region} labels and a
+// configurable d.topologyLabelPrefix variant of them, and falling back to the pool's name as the
+// zone if neither is set.
+func (d *ironcoreDriver) zoneAndRegionForMachine(ctx context.Context, machine *computev1alpha1.Machine) (zone, region string, err error) {
+	if machine.Spec.MachinePoolRef == nil {
+		return "", "", nil
+	}
+
+	pool := &computev1alpha1.MachinePool{}
+	poolKey := types.NamespacedName{Namespace: d.machinePoolNamespace, Name: machine.Spec.MachinePoolRef.Name}
+	if err := d.ironcoreClient.Get(ctx, poolKey, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return machine.Spec.MachinePoolRef.Name, "", nil
+		}
+		return "", "", err
+	}
+
+	zone = pool.Labels[corev1.LabelTopologyZone]
+	region = pool.Labels[corev1.LabelTopologyRegion]
+	if d.topologyLabelPrefix != "" {
+		if zone == "" {
+			zone = pool.Labels[d.topologyLabelPrefix+"zone"]
+		}
+		if region == "" {
+			region = pool.Labels[d.topologyLabelPrefix+"region"]
+		}
+	}
+	if zone == "" {
+		zone = pool.Name
+	}
+
+	return zone, region, nil
+}
+
+// ensureClusterNameLabel patches obj to carry LabelKeyClusterName, if it is not already set.
+func (d *ironcoreDriver) ensureClusterNameLabel(ctx context.Context, obj client.Object) error {
+	if d.clusterName == "" {
+		return nil
+	}
+	if obj.GetLabels()[LabelKeyClusterName] == d.clusterName {
+		return nil
+	}
+
+	base := obj.DeepCopyObject().(client.Object)
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[LabelKeyClusterName] = d.clusterName
+	obj.SetLabels(labels)
+	return d.ironcoreClient.Patch(ctx, obj, client.MergeFrom(base))
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	commonv1alpha1 "github.com/ironcore-dev/ironcore/api/common/v1alpha1"
+	computev1alpha1 "github.com/ironcore-dev/ironcore/api/compute/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+	"github.com/opensovereigncloud/cloud-provider-ironcore/pkg/cloudprovider/ironcore/metadata"
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+// conformance test: a gRPC driver fronting the reference server must behave exactly like the
+// in-process ironcoreDriver it wraps.
+var _ = Describe("InstanceDriver conformance", func() {
+	ns, cp, _, _ := SetupTest()
+
+	It("returns the same instance through the gRPC driver as through the in-process driver", func(ctx SpecContext) {
+		By("creating a machine")
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				Image:           "my-image:latest",
+				NetworkInterfaces: []computev1alpha1.NetworkInterface{
+					{Name: "my-nic"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, machine)
+
+		By("creating a network interface for the machine")
+		nic := &networkingv1alpha1.NetworkInterface{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      fmt.Sprintf("%s-my-nic", machine.Name),
+			},
+			Spec: networkingv1alpha1.NetworkInterfaceSpec{
+				NetworkRef: corev1.LocalObjectReference{Name: "my-network"},
+				IPs:        []networkingv1alpha1.IPSource{{Value: commonv1alpha1.MustParseNewIP("10.0.0.1")}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, nic)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, nic)
+
+		nicBase := nic.DeepCopy()
+		nic.Status.IPs = []commonv1alpha1.IP{commonv1alpha1.MustParseIP("10.0.0.1")}
+		Expect(k8sClient.Status().Patch(ctx, nic, client.MergeFrom(nicBase))).To(Succeed())
+
+		By("patching the machine to reference the network interface and report shutdown state")
+		machineBase := machine.DeepCopy()
+		machine.Spec.NetworkInterfaces[0].NetworkInterfaceSource = computev1alpha1.NetworkInterfaceSource{
+			NetworkInterfaceRef: &corev1.LocalObjectReference{Name: nic.Name},
+		}
+		machine.Status.State = computev1alpha1.MachineStateShutdown
+		machine.Status.NetworkInterfaces = []computev1alpha1.NetworkInterfaceStatus{{
+			Name:                "my-nic",
+			NetworkInterfaceRef: corev1.LocalObjectReference{Name: nic.Name},
+		}}
+		Expect(k8sClient.Patch(ctx, machine, client.MergeFrom(machineBase))).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		inProcessDriver := &ironcoreDriver{
+			ironcoreClient:     k8sClient,
+			namespace:          ns.Name,
+			defaultAddressType: corev1.NodeInternalIP,
+		}
+
+		By("serving the same driver logic over a bufconn gRPC server")
+		listener := bufconn.Listen(1024 * 1024)
+		server := grpc.NewServer()
+		driverv1.RegisterInstanceDriverServer(server, &instanceDriverServer{driver: inProcessDriver})
+		go func() { _ = server.Serve(listener) }()
+		DeferCleanup(server.Stop)
+
+		conn, err := grpc.NewClient("passthrough:///bufconn",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+
+		remoteDriver := &grpcDriver{client: driverv1.NewInstanceDriverClient(conn), namespace: ns.Name}
+
+		directResult, err := inProcessDriver.GetInstance(ctx, node.Name)
+		Expect(err).NotTo(HaveOccurred())
+
+		remoteResult, err := remoteDriver.GetInstance(ctx, node.Name)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remoteResult.ProviderId).To(Equal(directResult.ProviderId))
+		Expect(remoteResult.InstanceType).To(Equal(directResult.InstanceType))
+		Expect(remoteResult.Zone).To(Equal(directResult.Zone))
+		Expect(remoteResult.Region).To(Equal(directResult.Region))
+		Expect(remoteResult.Shutdown).To(Equal(directResult.Shutdown))
+		Expect(directResult.Shutdown).To(BeTrue())
+		Expect(remoteResult.NodeAddresses).To(HaveLen(len(directResult.NodeAddresses)))
+		Expect(directResult.NodeAddresses).NotTo(BeEmpty())
+		for i, addr := range directResult.NodeAddresses {
+			Expect(remoteResult.NodeAddresses[i].Type).To(Equal(addr.Type))
+			Expect(remoteResult.NodeAddresses[i].Address).To(Equal(addr.Address))
+		}
+	})
+})
+
+var _ = Describe("InstanceDriver metadata service fallback", func() {
+	ns, _, _, _ := SetupTest()
+
+	It("falls back to the metadata service when no Machine exists for the Node", func(ctx SpecContext) {
+		By("starting a metadata service serving a single node")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"providerID": "ironcore://foo/bare-metal-1", "zone": "zone-a"}`))
+		}))
+		DeferCleanup(server.Close)
+
+		driver := &ironcoreDriver{
+			ironcoreClient: k8sClient,
+			namespace:      ns.Name,
+			metadataClient: metadata.NewClient(server.URL, ""),
+		}
+
+		instance, err := driver.GetInstance(ctx, "bare-metal-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.ProviderId).To(Equal("ironcore://foo/bare-metal-1"))
+		Expect(instance.Zone).To(Equal("zone-a"))
+	})
+
+	It("returns NotFound if the metadata service also has no entry for the Node", func(ctx SpecContext) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		DeferCleanup(server.Close)
+
+		driver := &ironcoreDriver{
+			ironcoreClient: k8sClient,
+			namespace:      ns.Name,
+			metadataClient: metadata.NewClient(server.URL, ""),
+		}
+
+		_, err := driver.GetInstance(ctx, "unknown")
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+})
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+// grpcDriver dials an out-of-tree instance-driver process over the endpoint configured via
+// --instance-driver-endpoint and forwards GetInstance calls to it.
+type grpcDriver struct {
+	client    driverv1.InstanceDriverClient
+	namespace string
+}
+
+var _ instanceDriver = (*grpcDriver)(nil)
+
+// dialInstanceDriver connects to the instance driver listening on endpoint, e.g.
+// "unix:///var/run/ironcore-instance-driver.sock".
+func dialInstanceDriver(endpoint, namespace string) (*grpcDriver, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial instance driver at %s: %w", endpoint, err)
+	}
+	return &grpcDriver{client: driverv1.NewInstanceDriverClient(conn), namespace: namespace}, nil
+}
+
+func (d *grpcDriver) GetInstance(ctx context.Context, name string) (*driverv1.GetInstanceResponse, error) {
+	return d.client.GetInstance(ctx, &driverv1.GetInstanceRequest{
+		Namespace: d.namespace,
+		Name:      name,
+	})
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	cloudprovider "k8s.io/cloud-provider"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+// instances implements cloudprovider.InstancesV2 on top of an instanceDriver, which is either the
+// built-in ironcoreDriver or a gRPC out-of-tree driver dialed via --instance-driver-endpoint.
+type instances struct {
+	driver instanceDriver
+}
+
+func newInstances(driver instanceDriver) cloudprovider.InstancesV2 {
+	return &instances{driver: driver}
+}
+
+var _ cloudprovider.InstancesV2 = (*instances)(nil)
+
+func (o *instances) InstanceExists(ctx context.Context, node *corev1.Node) (bool, error) {
+	if _, err := o.getInstance(ctx, node); err != nil {
+		if err == cloudprovider.InstanceNotFound {
+			return false, cloudprovider.InstanceNotFound
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (o *instances) InstanceShutdown(ctx context.Context, node *corev1.Node) (bool, error) {
+	instance, err := o.getInstance(ctx, node)
+	if err != nil {
+		return false, err
+	}
+	return instance.Shutdown, nil
+}
+
+func (o *instances) InstanceMetadata(ctx context.Context, node *corev1.Node) (*cloudprovider.InstanceMetadata, error) {
+	instance, err := o.getInstance(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeAddresses := make([]corev1.NodeAddress, 0, len(instance.NodeAddresses))
+	for _, addr := range instance.NodeAddresses {
+		nodeAddresses = append(nodeAddresses, corev1.NodeAddress{
+			Type:    corev1.NodeAddressType(addr.Type),
+			Address: addr.Address,
+		})
+	}
+
+	return &cloudprovider.InstanceMetadata{
+		ProviderID:    instance.ProviderId,
+		InstanceType:  instance.InstanceType,
+		NodeAddresses: nodeAddresses,
+		Zone:          instance.Zone,
+		Region:        instance.Region,
+	}, nil
+}
+
+// getInstance resolves node through o.driver, translating a gRPC NotFound status into
+// cloudprovider.InstanceNotFound as required by the cloudprovider.InstancesV2 contract.
+func (o *instances) getInstance(ctx context.Context, node *corev1.Node) (*driverv1.GetInstanceResponse, error) {
+	instance, err := o.driver.GetInstance(ctx, node.Name)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, cloudprovider.InstanceNotFound
+		}
+		return nil, fmt.Errorf("failed to get instance for node %s: %w", node.Name, err)
+	}
+	return instance, nil
+}
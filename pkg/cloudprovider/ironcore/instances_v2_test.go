@@ -151,6 +151,176 @@ var _ = Describe("InstancesV2", func() {
 
 	})
 
+	It("should report addresses for every NIC, classified per-network", func(ctx SpecContext) {
+		By("instantiating the instances v2 provider")
+		var ok bool
+		instancesProvider, ok = (*cp).InstancesV2()
+		Expect(ok).To(BeTrue())
+
+		By("creating a second network for a storage VLAN classified as InternalDNS")
+		storageNetwork := &networkingv1alpha1.Network{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "storage-network-",
+				Annotations: map[string]string{
+					AnnotationKeyAddressType: string(corev1.NodeInternalDNS),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, storageNetwork)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, storageNetwork)
+
+		By("creating a machine with a primary and a storage NIC")
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				MachinePoolRef:  &corev1.LocalObjectReference{Name: "zone1"},
+				Image:           "my-image:latest",
+				NetworkInterfaces: []computev1alpha1.NetworkInterface{
+					{Name: "primary-nic"},
+					{Name: "storage-nic"},
+				},
+				Volumes: []computev1alpha1.Volume{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		primaryNic := &networkingv1alpha1.NetworkInterface{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-primary-nic", machine.Name), Namespace: ns.Name},
+			Spec: networkingv1alpha1.NetworkInterfaceSpec{
+				NetworkRef: corev1.LocalObjectReference{Name: network.Name},
+				IPs:        []networkingv1alpha1.IPSource{{Value: commonv1alpha1.MustParseNewIP("10.0.0.2")}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, primaryNic)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, primaryNic)
+
+		storageNic := &networkingv1alpha1.NetworkInterface{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-storage-nic", machine.Name), Namespace: ns.Name},
+			Spec: networkingv1alpha1.NetworkInterfaceSpec{
+				NetworkRef: corev1.LocalObjectReference{Name: storageNetwork.Name},
+				IPs:        []networkingv1alpha1.IPSource{{Value: commonv1alpha1.MustParseNewIP("10.1.0.2")}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, storageNic)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, storageNic)
+
+		for _, nic := range []*networkingv1alpha1.NetworkInterface{primaryNic, storageNic} {
+			base := nic.DeepCopy()
+			nic.Status.State = networkingv1alpha1.NetworkInterfaceStateAvailable
+			nic.Status.IPs = []commonv1alpha1.IP{commonv1alpha1.MustParseIP(nic.Spec.IPs[0].Value.String())}
+			Expect(k8sClient.Status().Patch(ctx, nic, client.MergeFrom(base))).To(Succeed())
+		}
+
+		machineBase := machine.DeepCopy()
+		machine.Status.State = computev1alpha1.MachineStateRunning
+		machine.Status.NetworkInterfaces = []computev1alpha1.NetworkInterfaceStatus{
+			{Name: "primary-nic", NetworkInterfaceRef: corev1.LocalObjectReference{Name: primaryNic.Name}},
+			{Name: "storage-nic", NetworkInterfaceRef: corev1.LocalObjectReference{Name: storageNic.Name}},
+		}
+		Expect(k8sClient.Patch(ctx, machine, client.MergeFrom(machineBase))).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		Eventually(func(g Gomega) {
+			instanceMetadata, err := instancesProvider.InstanceMetadata(ctx, node)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(instanceMetadata.NodeAddresses).To(ContainElements(
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+				corev1.NodeAddress{Type: corev1.NodeInternalDNS, Address: "10.1.0.2"},
+			))
+		}).Should(Succeed())
+	})
+
+	It("should derive zone and region from MachinePool topology labels", func(ctx SpecContext) {
+		By("instantiating the instances v2 provider")
+		var ok bool
+		instancesProvider, ok = (*cp).InstancesV2()
+		Expect(ok).To(BeTrue())
+
+		By("creating a MachinePool with well-known topology labels")
+		pool := &computev1alpha1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "pool-with-labels",
+				Labels: map[string]string{
+					corev1.LabelTopologyZone:   "eu-west-1a",
+					corev1.LabelTopologyRegion: "eu-west-1",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, pool)
+
+		By("creating a machine referencing that pool")
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				MachinePoolRef:  &corev1.LocalObjectReference{Name: pool.Name},
+				Image:           "my-image:latest",
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		instanceMetadata, err := instancesProvider.InstanceMetadata(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instanceMetadata.Zone).To(Equal("eu-west-1a"))
+		Expect(instanceMetadata.Region).To(Equal("eu-west-1"))
+	})
+
+	It("should fall back to the MachinePool name when it has no topology labels", func(ctx SpecContext) {
+		By("instantiating the instances v2 provider")
+		var ok bool
+		instancesProvider, ok = (*cp).InstancesV2()
+		Expect(ok).To(BeTrue())
+
+		By("creating a MachinePool without topology labels")
+		pool := &computev1alpha1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "pool-without-labels",
+			},
+		}
+		Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, pool)
+
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				MachinePoolRef:  &corev1.LocalObjectReference{Name: pool.Name},
+				Image:           "my-image:latest",
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		instanceMetadata, err := instancesProvider.InstanceMetadata(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instanceMetadata.Zone).To(Equal(pool.Name))
+		Expect(instanceMetadata.Region).To(BeEmpty())
+	})
+
 	It("should get InstanceNotFound if no Machine exists for Node", func(ctx SpecContext) {
 		By("creating a node object with a provider ID referencing non existing machine")
 		node := &corev1.Node{
@@ -198,8 +368,117 @@ var _ = Describe("InstancesV2", func() {
 		Expect(err).To(Equal(cloudprovider.InstanceNotFound))
 		Expect(ok).To(BeFalse())
 	})
+
+	It("should report both IPv4 and IPv6 addresses for a dual-stack NIC", func(ctx SpecContext) {
+		By("instantiating the instances v2 provider")
+		var ok bool
+		instancesProvider, ok = (*cp).InstancesV2()
+		Expect(ok).To(BeTrue())
+
+		By("creating a machine with a dual-stack network interface")
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				MachinePoolRef:  &corev1.LocalObjectReference{Name: "zone1"},
+				Image:           "my-image:latest",
+				NetworkInterfaces: []computev1alpha1.NetworkInterface{
+					{Name: "dual-stack-nic"},
+				},
+				Volumes: []computev1alpha1.Volume{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		nic := &networkingv1alpha1.NetworkInterface{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-dual-stack-nic", machine.Name), Namespace: ns.Name},
+			Spec: networkingv1alpha1.NetworkInterfaceSpec{
+				NetworkRef: corev1.LocalObjectReference{Name: network.Name},
+				IPs: []networkingv1alpha1.IPSource{
+					{Value: commonv1alpha1.MustParseNewIP("10.0.0.3")},
+					{Value: commonv1alpha1.MustParseNewIP("2001:db8::3")},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, nic)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, nic)
+
+		nicBase := nic.DeepCopy()
+		nic.Status.State = networkingv1alpha1.NetworkInterfaceStateAvailable
+		nic.Status.IPs = []commonv1alpha1.IP{
+			commonv1alpha1.MustParseIP("10.0.0.3"),
+			commonv1alpha1.MustParseIP("2001:db8::3"),
+		}
+		Expect(k8sClient.Status().Patch(ctx, nic, client.MergeFrom(nicBase))).To(Succeed())
+
+		machineBase := machine.DeepCopy()
+		machine.Status.State = computev1alpha1.MachineStateRunning
+		machine.Status.NetworkInterfaces = []computev1alpha1.NetworkInterfaceStatus{
+			{Name: "dual-stack-nic", NetworkInterfaceRef: corev1.LocalObjectReference{Name: nic.Name}},
+		}
+		Expect(k8sClient.Patch(ctx, machine, client.MergeFrom(machineBase))).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		Eventually(func(g Gomega) {
+			instanceMetadata, err := instancesProvider.InstanceMetadata(ctx, node)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(instanceMetadata.NodeAddresses).To(ContainElements(
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.3"},
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "2001:db8::3"},
+			))
+		}).Should(Succeed())
+	})
 })
 
-func getProviderID(namespace, machineName string) string {
-	return fmt.Sprintf("%s://%s/%s", ProviderName, namespace, machineName)
-}
+var _ = Describe("InstancesV2 cross-namespace MachinePool", func() {
+	ns, machinePoolNs, cp, _, _ := SetupTestCrossNamespace()
+
+	It("should derive zone and region from a MachinePool in a different namespace", func(ctx SpecContext) {
+		By("instantiating the instances v2 provider")
+		instancesProvider, ok := (*cp).InstancesV2()
+		Expect(ok).To(BeTrue())
+
+		By("creating a MachinePool in the dedicated MachinePool namespace")
+		pool := &computev1alpha1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machinePoolNs.Name,
+				Name:      "cross-namespace-pool",
+				Labels: map[string]string{
+					corev1.LabelTopologyZone:   "eu-west-1a",
+					corev1.LabelTopologyRegion: "eu-west-1",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, pool)
+
+		By("creating a machine in the cluster namespace referencing that pool")
+		machine := &computev1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "machine-",
+			},
+			Spec: computev1alpha1.MachineSpec{
+				MachineClassRef: corev1.LocalObjectReference{Name: "machine-class"},
+				MachinePoolRef:  &corev1.LocalObjectReference{Name: pool.Name},
+				Image:           "my-image:latest",
+			},
+		}
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: machine.Name}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, node)
+
+		instanceMetadata, err := instancesProvider.InstanceMetadata(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instanceMetadata.Zone).To(Equal("eu-west-1a"))
+		Expect(instanceMetadata.Region).To(Equal("eu-west-1"))
+	})
+})
@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	cloudprovider "k8s.io/cloud-provider"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	computev1alpha1 "github.com/ironcore-dev/ironcore/api/compute/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+	"github.com/opensovereigncloud/cloud-provider-ironcore/pkg/cloudprovider/ironcore/metadata"
+)
+
+const (
+	// ProviderName is the name of the ironcore cloud provider.
+	ProviderName = "ironcore"
+
+	// LabelKeyClusterName is the label applied to ironcore resources (Machines, NetworkInterfaces, ...)
+	// that are owned by a Kubernetes cluster the cloud provider is managing on behalf of.
+	LabelKeyClusterName = "cloud-provider.ironcore.dev/cluster-name"
+
+	// labelKeyNodeName records the name of the Kubernetes Node a NetworkInterface is acting as a
+	// route target for, so ListRoutes can map NetworkInterfaces back to Nodes.
+	labelKeyNodeName = "cloud-provider.ironcore.dev/node-name"
+
+	// AnnotationKeyAddressType overrides, on an ironcore Network, how IPs of NetworkInterfaces
+	// attached to it are classified in NodeAddresses (one of corev1.NodeAddressType). Falls back
+	// to the --default-address-type flag when unset.
+	AnnotationKeyAddressType = "cloud-provider.ironcore.dev/address-type"
+
+	defaultAddressTypeFlagName = "default-address-type"
+
+	topologyLabelPrefixFlagName = "machine-pool-topology-label-prefix"
+
+	instanceDriverEndpointFlagName = "instance-driver-endpoint"
+
+	metadataEndpointFlagName = "metadata-service-endpoint"
+
+	metadataAuthTokenFlagName = "metadata-service-auth-token"
+
+	// controllerClientName is the user passed to ControllerClientBuilder.Config to obtain the
+	// target cluster client used by Initialize, matching the convention used by the upstream
+	// generic controller manager's built-in controllers.
+	controllerClientName = "cloud-controller-manager"
+)
+
+// defaultAddressType is populated by AddExtraFlags and used as the fallback NodeAddressType for
+// NetworkInterfaces whose Network has no AnnotationKeyAddressType annotation.
+var defaultAddressType = string(corev1.NodeInternalIP)
+
+// topologyLabelPrefix is populated by AddExtraFlags and, if set, is checked for a
+// "<prefix>zone"/"<prefix>region" pair of labels on a MachinePool before falling back to the
+// pool's name, in addition to the well-known topology.kubernetes.io/{zone,region} labels.
+var topologyLabelPrefix string
+
+// instanceDriverEndpoint is populated by AddExtraFlags. If set, instance discovery is delegated
+// to an out-of-tree driver dialed at this endpoint (e.g. "unix:///var/run/driver.sock") instead
+// of the built-in ironcoreDriver.
+var instanceDriverEndpoint string
+
+// metadataEndpoint is populated by AddExtraFlags. If set, the ironcoreDriver falls back to
+// querying a link-local instance-metadata service at this endpoint for Nodes that have no
+// matching Machine object.
+var metadataEndpoint string
+
+// metadataAuthToken is populated by AddExtraFlags and sent as a bearer token when querying
+// metadataEndpoint, if both are set.
+var metadataAuthToken string
+
+// CloudConfig is the configuration read from the file passed via --cloud-config.
+type CloudConfig struct {
+	// Namespace is the namespace in the ironcore cluster the Machines/NetworkInterfaces/... of
+	// this Kubernetes cluster live in.
+	Namespace string `json:"namespace"`
+	// NetworkName is the name of the ironcore Network the cluster's Machines are attached to.
+	NetworkName string `json:"networkName"`
+	// ClusterName is a human-readable identifier of the Kubernetes cluster used to label
+	// ironcore resources so they can be attributed back to it.
+	ClusterName string `json:"clusterName"`
+	// MachinePoolNamespace is the namespace MachinePool objects are read from when resolving
+	// zone/region topology. Defaults to Namespace if unset, allowing a cluster's Machines and the
+	// MachinePools they reference to live in different namespaces.
+	MachinePoolNamespace string `json:"machinePoolNamespace"`
+}
+
+func (c CloudConfig) validate() error {
+	if c.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	return nil
+}
+
+// Cloud is an implementation of cloudprovider.Interface for ironcore.
+type Cloud struct {
+	targetClient   client.Client
+	ironcoreClient client.Client
+
+	namespace            string
+	machinePoolNamespace string
+	networkName          string
+	clusterName          string
+}
+
+var _ cloudprovider.Interface = (*Cloud)(nil)
+
+func init() {
+	cloudprovider.RegisterCloudProvider(ProviderName, func(config io.Reader) (cloudprovider.Interface, error) {
+		cfg, err := readCloudConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cloud config: %w", err)
+		}
+		return newCloud(cfg)
+	})
+}
+
+func readCloudConfig(config io.Reader) (CloudConfig, error) {
+	var cfg CloudConfig
+	if config == nil {
+		return cfg, fmt.Errorf("no cloud-config file given")
+	}
+	data, err := io.ReadAll(config)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func newCloud(cfg CloudConfig) (*Cloud, error) {
+	return &Cloud{
+		namespace:            cfg.Namespace,
+		machinePoolNamespace: cfg.MachinePoolNamespace,
+		networkName:          cfg.NetworkName,
+		clusterName:          cfg.ClusterName,
+	}, nil
+}
+
+// Initialize is called by the generic controller manager once the clients it needs are available.
+// It builds the targetClient from clientBuilder, which is wired up against the Kubernetes cluster
+// this CCM is managing, and the ironcoreClient from the ambient kubeconfig (in-cluster config, or
+// $KUBECONFIG/$HOME/.kube/config outside a cluster), which points at the ironcore API server the
+// cluster's Machines/NetworkInterfaces/... live in.
+func (c *Cloud) Initialize(clientBuilder cloudprovider.ControllerClientBuilder, stop <-chan struct{}) {
+	if err := computev1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		klog.Fatalf("unable to add compute/v1alpha1 to scheme: %v", err)
+	}
+	if err := networkingv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		klog.Fatalf("unable to add networking/v1alpha1 to scheme: %v", err)
+	}
+
+	targetConfig, err := clientBuilder.Config(controllerClientName)
+	if err != nil {
+		klog.Fatalf("unable to build target cluster client config: %v", err)
+	}
+	targetClient, err := client.New(targetConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		klog.Fatalf("unable to create target cluster client: %v", err)
+	}
+
+	ironcoreClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		klog.Fatalf("unable to create ironcore client: %v", err)
+	}
+
+	c.targetClient = targetClient
+	c.ironcoreClient = ironcoreClient
+}
+
+// LoadBalancer returns a balancer interface. Also returns true if the interface is supported,
+// false otherwise.
+func (c *Cloud) LoadBalancer() (cloudprovider.LoadBalancer, bool) {
+	return newLoadBalancer(c.ironcoreClient, c.targetClient, c.namespace, c.networkName, c.clusterName), true
+}
+
+// Instances returns an instances interface. Also returns true if the interface is supported,
+// false otherwise. Deprecated in favor of InstancesV2.
+func (c *Cloud) Instances() (cloudprovider.Instances, bool) {
+	return nil, false
+}
+
+// InstancesV2 returns an instancesV2 interface. Also returns true if the interface is supported,
+// false otherwise.
+func (c *Cloud) InstancesV2() (cloudprovider.InstancesV2, bool) {
+	driver, err := c.instanceDriver()
+	if err != nil {
+		klog.Fatalf("unable to set up instance driver: %v", err)
+	}
+	return newInstances(driver), true
+}
+
+// instanceDriver returns the gRPC out-of-tree driver dialed at --instance-driver-endpoint if one
+// was configured, or the built-in ironcoreDriver otherwise.
+func (c *Cloud) instanceDriver() (instanceDriver, error) {
+	if instanceDriverEndpoint != "" {
+		return dialInstanceDriver(instanceDriverEndpoint, c.namespace)
+	}
+
+	machinePoolNamespace := c.machinePoolNamespace
+	if machinePoolNamespace == "" {
+		machinePoolNamespace = c.namespace
+	}
+
+	var metadataClient *metadata.Client
+	if metadataEndpoint != "" {
+		metadataClient = metadata.NewClient(metadataEndpoint, metadataAuthToken)
+	}
+
+	return &ironcoreDriver{
+		ironcoreClient:       c.ironcoreClient,
+		namespace:            c.namespace,
+		machinePoolNamespace: machinePoolNamespace,
+		clusterName:          c.clusterName,
+		defaultAddressType:   corev1.NodeAddressType(defaultAddressType),
+		topologyLabelPrefix:  topologyLabelPrefix,
+		metadataClient:       metadataClient,
+	}, nil
+}
+
+// Zones returns a zones interface. Also returns true if the interface is supported, false
+// otherwise. Deprecated in favor of the Zone field in InstanceMetadata returned by InstancesV2.
+func (c *Cloud) Zones() (cloudprovider.Zones, bool) {
+	return nil, false
+}
+
+// Clusters returns a clusters interface. Also returns true if the interface is supported, false
+// otherwise.
+func (c *Cloud) Clusters() (cloudprovider.Clusters, bool) {
+	return nil, false
+}
+
+// Routes returns a routes interface along with whether the interface is supported.
+func (c *Cloud) Routes() (cloudprovider.Routes, bool) {
+	return newRoutes(c.ironcoreClient, c.targetClient, c.namespace, c.networkName, c.clusterName), true
+}
+
+// ProviderName returns the cloud provider ID.
+func (c *Cloud) ProviderName() string {
+	return ProviderName
+}
+
+// HasClusterID returns true if the cluster has a clusterID.
+func (c *Cloud) HasClusterID() bool {
+	return c.clusterName != ""
+}
+
+// AddExtraFlags is called by the CCM binary to register provider-specific flags on the shared
+// pflag.FlagSet before the command line is parsed.
+func AddExtraFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&defaultAddressType, defaultAddressTypeFlagName, defaultAddressType,
+		"Default NodeAddressType used to classify a NetworkInterface's IPs when its Network has no "+AnnotationKeyAddressType+" annotation.")
+	fs.StringVar(&topologyLabelPrefix, topologyLabelPrefixFlagName, topologyLabelPrefix,
+		"Additional label prefix checked for \"<prefix>zone\"/\"<prefix>region\" on a MachinePool, alongside the well-known topology.kubernetes.io/{zone,region} labels.")
+	fs.StringVar(&instanceDriverEndpoint, instanceDriverEndpointFlagName, instanceDriverEndpoint,
+		"Dial an out-of-tree instance driver at this endpoint (e.g. unix:///var/run/driver.sock) instead of resolving instances against the ironcore API directly.")
+	fs.StringVar(&metadataEndpoint, metadataEndpointFlagName, metadataEndpoint,
+		"Instance-metadata service endpoint (e.g. http://169.254.169.254) queried as a fallback for Nodes that have no matching Machine object. Disabled if unset.")
+	fs.StringVar(&metadataAuthToken, metadataAuthTokenFlagName, metadataAuthToken,
+		"Bearer token sent when querying --"+metadataEndpointFlagName+".")
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	cloudprovider "k8s.io/cloud-provider"
+
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+)
+
+var _ = Describe("LoadBalancer", func() {
+	var (
+		lbProvider cloudprovider.LoadBalancer
+	)
+	ns, cp, _, clusterName := SetupTest()
+
+	BeforeEach(func() {
+		var ok bool
+		lbProvider, ok = (*cp).LoadBalancer()
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should create a public load balancer for a Service of type LoadBalancer", func(ctx SpecContext) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "my-service",
+				UID:       uuid.NewUUID(),
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{Port: 80, Protocol: corev1.ProtocolTCP},
+				},
+			},
+		}
+
+		By("ensuring the load balancer")
+		_, err := lbProvider.EnsureLoadBalancer(ctx, clusterName, service, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("getting the created ironcore LoadBalancer object")
+		lb := &networkingv1alpha1.LoadBalancer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: ns.Name,
+			Name:      lbProvider.GetLoadBalancerName(ctx, clusterName, service),
+		}, lb)).To(Succeed())
+
+		Expect(lb.Spec.Type).To(Equal(networkingv1alpha1.LoadBalancerTypePublic))
+		Expect(lb.Labels).To(HaveKeyWithValue(LabelKeyClusterName, clusterName))
+		Expect(lb.Spec.Ports).To(HaveLen(1))
+
+		By("getting the load balancer through the provider again")
+		_, exists, err := lbProvider.GetLoadBalancer(ctx, clusterName, service)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("should create an internal load balancer when annotated", func(ctx SpecContext) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "my-internal-service",
+				UID:       uuid.NewUUID(),
+				Annotations: map[string]string{
+					AnnotationKeyLoadBalancerType: LoadBalancerTypeInternal,
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{{Port: 443, Protocol: corev1.ProtocolTCP}},
+			},
+		}
+
+		_, err := lbProvider.EnsureLoadBalancer(ctx, clusterName, service, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		lb := &networkingv1alpha1.LoadBalancer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: ns.Name,
+			Name:      lbProvider.GetLoadBalancerName(ctx, clusterName, service),
+		}, lb)).To(Succeed())
+		Expect(lb.Spec.Type).To(Equal(networkingv1alpha1.LoadBalancerTypeInternal))
+	})
+
+	It("should ignore LoadBalancerSourceRanges, since it is not yet wired into the ironcore LoadBalancer spec", func(ctx SpecContext) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "my-source-ranges-service",
+				UID:       uuid.NewUUID(),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				Ports:                    []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			},
+		}
+
+		_, err := lbProvider.EnsureLoadBalancer(ctx, clusterName, service, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		lb := &networkingv1alpha1.LoadBalancer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: ns.Name,
+			Name:      lbProvider.GetLoadBalancerName(ctx, clusterName, service),
+		}, lb)).To(Succeed())
+		Expect(lb.Spec.Type).To(Equal(networkingv1alpha1.LoadBalancerTypePublic))
+	})
+
+	It("should delete the load balancer", func(ctx SpecContext) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "my-deleted-service",
+				UID:       uuid.NewUUID(),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+			},
+		}
+		_, err := lbProvider.EnsureLoadBalancer(ctx, clusterName, service, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(lbProvider.EnsureLoadBalancerDeleted(ctx, clusterName, service)).To(Succeed())
+
+		_, exists, err := lbProvider.GetLoadBalancer(ctx, clusterName, service)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+})
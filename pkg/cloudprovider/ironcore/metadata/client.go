@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metadata implements a client for a link-local instance-metadata HTTP service (in the
+// style of the EC2/CloudStack DHCP-served metadata services), used as a fallback source of
+// InstanceMetadata for Nodes that have no matching ironcore Machine object.
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+// ErrNotFound is returned by GetInstance when the metadata endpoint has no entry for a Node.
+var ErrNotFound = errors.New("no metadata found for node")
+
+// Client queries a configured instance-metadata endpoint for the instance backing a Node.
+type Client struct {
+	endpoint   string
+	authToken  string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client querying endpoint (e.g. "http://169.254.169.254"), authenticating
+// with authToken as a bearer token if set.
+func NewClient(endpoint, authToken string) *Client {
+	return &Client{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		authToken: authToken,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// instanceMetadata mirrors driverv1.GetInstanceResponse as the JSON payload served by the
+// metadata endpoint for a given Node name.
+type instanceMetadata struct {
+	ProviderID    string                 `json:"providerID"`
+	InstanceType  string                 `json:"instanceType"`
+	NodeAddresses []driverv1.NodeAddress `json:"nodeAddresses"`
+	Zone          string                 `json:"zone"`
+	Region        string                 `json:"region"`
+	Shutdown      bool                   `json:"shutdown"`
+}
+
+// GetInstance queries the metadata endpoint for the instance backing the Node named name.
+func (c *Client) GetInstance(ctx context.Context, name string) (*driverv1.GetInstanceResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/latest/meta-data/%s", c.endpoint, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build metadata request: %w", err)
+	}
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metadata endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata endpoint returned status %d for node %s", resp.StatusCode, name)
+	}
+
+	var m instanceMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata response for node %s: %w", name, err)
+	}
+
+	nodeAddresses := make([]*driverv1.NodeAddress, 0, len(m.NodeAddresses))
+	for _, addr := range m.NodeAddresses {
+		addr := addr
+		nodeAddresses = append(nodeAddresses, &addr)
+	}
+
+	return &driverv1.GetInstanceResponse{
+		ProviderId:    m.ProviderID,
+		InstanceType:  m.InstanceType,
+		NodeAddresses: nodeAddresses,
+		Zone:          m.Zone,
+		Region:        m.Region,
+		Shutdown:      m.Shutdown,
+	}, nil
+}
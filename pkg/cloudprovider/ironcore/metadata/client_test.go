@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetInstance(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/meta-data/node-a", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer s3cr3t"; got != want {
+			t.Errorf("Authorization header = %q, want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"providerID": "ironcore://foo/node-a",
+			"instanceType": "machine-class",
+			"nodeAddresses": [{"type": "InternalIP", "address": "10.0.0.1"}],
+			"zone": "zone-a"
+		}`))
+	})
+	mux.HandleFunc("/latest/meta-data/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "s3cr3t")
+
+	instance, err := client.GetInstance(context.Background(), "node-a")
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	if instance.ProviderId != "ironcore://foo/node-a" {
+		t.Errorf("ProviderId = %q, want %q", instance.ProviderId, "ironcore://foo/node-a")
+	}
+	if instance.Zone != "zone-a" {
+		t.Errorf("Zone = %q, want %q", instance.Zone, "zone-a")
+	}
+	if len(instance.NodeAddresses) != 1 || instance.NodeAddresses[0].Address != "10.0.0.1" {
+		t.Errorf("NodeAddresses = %v, want a single 10.0.0.1 entry", instance.NodeAddresses)
+	}
+
+	_, err = client.GetInstance(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetInstance() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	cloudprovider "k8s.io/cloud-provider"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1alpha1 "github.com/ironcore-dev/ironcore/api/common/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+)
+
+type routes struct {
+	ironcoreClient client.Client
+	targetClient   client.Client
+	namespace      string
+	networkName    string
+	clusterName    string
+}
+
+func newRoutes(ironcoreClient, targetClient client.Client, namespace, networkName, clusterName string) cloudprovider.Routes {
+	return &routes{
+		ironcoreClient: ironcoreClient,
+		targetClient:   targetClient,
+		namespace:      namespace,
+		networkName:    networkName,
+		clusterName:    clusterName,
+	}
+}
+
+var _ cloudprovider.Routes = (*routes)(nil)
+
+// ListRoutes returns the pod CIDR routes currently advertised on this cluster's NetworkInterfaces.
+func (o *routes) ListRoutes(ctx context.Context, clusterName string) ([]*cloudprovider.Route, error) {
+	nicList := &networkingv1alpha1.NetworkInterfaceList{}
+	if err := o.ironcoreClient.List(ctx, nicList,
+		client.InNamespace(o.namespace),
+		client.MatchingLabels{LabelKeyClusterName: o.clusterName},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	var rs []*cloudprovider.Route
+	for _, nic := range nicList.Items {
+		nodeName := nic.Labels[labelKeyNodeName]
+		if nodeName == "" {
+			continue
+		}
+		for _, prefix := range nic.Spec.Prefixes {
+			if prefix.Prefix == nil {
+				continue
+			}
+			rs = append(rs, &cloudprovider.Route{
+				Name:            fmt.Sprintf("%s-%s", nodeName, prefix.Prefix.String()),
+				TargetNode:      types.NodeName(nodeName),
+				DestinationCIDR: prefix.Prefix.String(),
+			})
+		}
+	}
+	return rs, nil
+}
+
+// CreateRoute advertises route.DestinationCIDR as an additional routed prefix on the
+// NetworkInterface belonging to route.TargetNode's Machine.
+func (o *routes) CreateRoute(ctx context.Context, clusterName string, nameHint string, route *cloudprovider.Route) error {
+	prefix, err := netip.ParsePrefix(route.DestinationCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid destination CIDR %q: %w", route.DestinationCIDR, err)
+	}
+
+	nic, err := o.getNetworkInterfaceForNode(ctx, string(route.TargetNode))
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range nic.Spec.Prefixes {
+		if existing.Prefix != nil && existing.Prefix.String() == prefix.String() {
+			return nil
+		}
+	}
+
+	base := nic.DeepCopy()
+	nic.Labels = mergeStringMaps(nic.Labels, map[string]string{
+		LabelKeyClusterName: o.clusterName,
+		labelKeyNodeName:    string(route.TargetNode),
+	})
+	nic.Spec.Prefixes = append(nic.Spec.Prefixes, networkingv1alpha1.IPPrefixSource{
+		Prefix: commonv1alpha1.MustParseNewIPPrefix(prefix.String()),
+	})
+	if err := o.ironcoreClient.Patch(ctx, nic, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to patch network interface %s with route for node %s: %w", client.ObjectKeyFromObject(nic), route.TargetNode, err)
+	}
+	return nil
+}
+
+// DeleteRoute removes the previously advertised route.DestinationCIDR prefix again.
+func (o *routes) DeleteRoute(ctx context.Context, clusterName string, route *cloudprovider.Route) error {
+	prefix, err := netip.ParsePrefix(route.DestinationCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid destination CIDR %q: %w", route.DestinationCIDR, err)
+	}
+
+	nic, err := o.getNetworkInterfaceForNode(ctx, string(route.TargetNode))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	base := nic.DeepCopy()
+	filtered := nic.Spec.Prefixes[:0]
+	for _, existing := range nic.Spec.Prefixes {
+		if existing.Prefix != nil && existing.Prefix.String() == prefix.String() {
+			continue
+		}
+		filtered = append(filtered, existing)
+	}
+	nic.Spec.Prefixes = filtered
+
+	if err := o.ironcoreClient.Patch(ctx, nic, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to patch network interface %s to remove route for node %s: %w", client.ObjectKeyFromObject(nic), route.TargetNode, err)
+	}
+	return nil
+}
+
+func (o *routes) getNetworkInterfaceForNode(ctx context.Context, nodeName string) (*networkingv1alpha1.NetworkInterface, error) {
+	nic := &networkingv1alpha1.NetworkInterface{}
+	if err := o.ironcoreClient.Get(ctx, types.NamespacedName{Namespace: o.namespace, Name: fmt.Sprintf("%s-%s", nodeName, o.networkName)}, nic); err != nil {
+		return nil, fmt.Errorf("failed to get network interface for node %s: %w", nodeName, err)
+	}
+	return nic, nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cloudprovider "k8s.io/cloud-provider"
+
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+)
+
+var _ = Describe("Routes", func() {
+	var (
+		routesProvider cloudprovider.Routes
+	)
+	ns, cp, network, clusterName := SetupTest()
+
+	BeforeEach(func(ctx SpecContext) {
+		var ok bool
+		routesProvider, ok = (*cp).Routes()
+		Expect(ok).To(BeTrue())
+
+		By("creating a network interface for a node")
+		nic := &networkingv1alpha1.NetworkInterface{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      fmt.Sprintf("node-1-%s", network.Name),
+			},
+			Spec: networkingv1alpha1.NetworkInterfaceSpec{
+				NetworkRef: corev1.LocalObjectReference{Name: network.Name},
+			},
+		}
+		Expect(k8sClient.Create(ctx, nic)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, nic)
+	})
+
+	It("should create and list a route for a node's pod CIDR", func(ctx SpecContext) {
+		route := &cloudprovider.Route{
+			TargetNode:      types.NodeName("node-1"),
+			DestinationCIDR: "10.244.0.0/24",
+		}
+
+		Expect(routesProvider.CreateRoute(ctx, clusterName, "route-1", route)).To(Succeed())
+
+		routeList, err := routesProvider.ListRoutes(ctx, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeList).To(ContainElement(SatisfyAll(
+			HaveField("TargetNode", types.NodeName("node-1")),
+			HaveField("DestinationCIDR", "10.244.0.0/24"),
+		)))
+	})
+
+	It("should delete a previously created route", func(ctx SpecContext) {
+		route := &cloudprovider.Route{
+			TargetNode:      types.NodeName("node-1"),
+			DestinationCIDR: "10.244.1.0/24",
+		}
+		Expect(routesProvider.CreateRoute(ctx, clusterName, "route-2", route)).To(Succeed())
+		Expect(routesProvider.DeleteRoute(ctx, clusterName, route)).To(Succeed())
+
+		routeList, err := routesProvider.ListRoutes(ctx, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeList).NotTo(ContainElement(SatisfyAll(
+			HaveField("TargetNode", types.NodeName("node-1")),
+			HaveField("DestinationCIDR", "10.244.1.0/24"),
+		)))
+	})
+})
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	driverv1 "github.com/opensovereigncloud/cloud-provider-ironcore/pkg/driver/v1"
+)
+
+// instanceDriverServer exposes ironcoreDriver as a driverv1.InstanceDriverServer, so it can be
+// run as the reference out-of-tree driver process behind --instance-driver-endpoint.
+type instanceDriverServer struct {
+	driverv1.UnimplementedInstanceDriverServer
+
+	driver *ironcoreDriver
+}
+
+// NewInstanceDriverServer returns the reference InstanceDriver gRPC server implementation,
+// backed directly by the ironcore API. It is the same logic the CCM runs in-process by default;
+// running it out-of-tree is only useful to prove out custom driver backends against a known-good
+// conformance partner.
+func NewInstanceDriverServer(ironcoreClient client.Client, cfg CloudConfig) driverv1.InstanceDriverServer {
+	machinePoolNamespace := cfg.MachinePoolNamespace
+	if machinePoolNamespace == "" {
+		machinePoolNamespace = cfg.Namespace
+	}
+
+	return &instanceDriverServer{
+		driver: &ironcoreDriver{
+			ironcoreClient:       ironcoreClient,
+			namespace:            cfg.Namespace,
+			machinePoolNamespace: machinePoolNamespace,
+			clusterName:          cfg.ClusterName,
+			defaultAddressType:   "InternalIP",
+		},
+	}
+}
+
+// GetInstance honors req.Namespace, if set, as an override of the namespace the server was
+// started with, so one driver process can serve GetInstance calls on behalf of several CCMs
+// (and therefore namespaces) sharing the same ironcore API server.
+func (s *instanceDriverServer) GetInstance(ctx context.Context, req *driverv1.GetInstanceRequest) (*driverv1.GetInstanceResponse, error) {
+	driver := *s.driver
+	if req.Namespace != "" {
+		driver.namespace = req.Namespace
+	}
+	return driver.GetInstance(ctx, req.Name)
+}
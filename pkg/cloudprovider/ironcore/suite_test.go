@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ironcore
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes/scheme"
+	cloudprovider "k8s.io/cloud-provider"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	computev1alpha1 "github.com/ironcore-dev/ironcore/api/compute/v1alpha1"
+	networkingv1alpha1 "github.com/ironcore-dev/ironcore/api/networking/v1alpha1"
+)
+
+const (
+	pollingInterval      = 50 * time.Millisecond
+	eventuallyTimeout    = 3 * time.Second
+	consistentlyDuration = 1 * time.Second
+)
+
+var (
+	k8sClient client.Client
+	testEnv   *envtest.Environment
+)
+
+func TestIroncore(t *testing.T) {
+	SetDefaultEventuallyPollingInterval(pollingInterval)
+	SetDefaultEventuallyTimeout(eventuallyTimeout)
+	SetDefaultConsistentlyPollingInterval(pollingInterval)
+	SetDefaultConsistentlyDuration(consistentlyDuration)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ironcore Cloud Provider Suite")
+}
+
+var _ = BeforeSuite(func(ctx SpecContext) {
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "..", "config", "crd", "bases"),
+		},
+		ErrorIfCRDPathMissing: false,
+	}
+
+	Expect(computev1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(networkingv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	cfg, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	komega.SetClient(k8sClient)
+}, NodeTimeout(60*time.Second))
+
+var _ = AfterSuite(func() {
+	Expect(testEnv.Stop()).To(Succeed())
+})
+
+// SetupTest creates a fresh namespace and Network for every test and returns an ironcore
+// cloudprovider.Interface wired up against the envtest API server.
+func SetupTest() (*corev1.Namespace, *cloudprovider.Interface, *networkingv1alpha1.Network, string) {
+	ns := &corev1.Namespace{}
+	network := &networkingv1alpha1.Network{}
+	cp := new(cloudprovider.Interface)
+	clusterName := fmt.Sprintf("cluster-%s", utilrand.String(8))
+
+	BeforeEach(func(ctx SpecContext) {
+		*ns = corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "testns-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ns)
+
+		*network = networkingv1alpha1.Network{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "network-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, network)).To(Succeed())
+
+		*cp = &Cloud{
+			ironcoreClient: k8sClient,
+			targetClient:   k8sClient,
+			namespace:      ns.Name,
+			networkName:    network.Name,
+			clusterName:    clusterName,
+		}
+	})
+
+	return ns, cp, network, clusterName
+}
+
+// SetupTestCrossNamespace is a variant of SetupTest for exercising machinePoolNamespace, which
+// lets a cluster's Machines reference MachinePools living in a different namespace. It creates a
+// second namespace for MachinePools alongside the one SetupTest creates for everything else, and
+// returns it in addition to SetupTest's usual results.
+func SetupTestCrossNamespace() (*corev1.Namespace, *corev1.Namespace, *cloudprovider.Interface, *networkingv1alpha1.Network, string) {
+	ns, cp, network, clusterName := SetupTest()
+	machinePoolNs := &corev1.Namespace{}
+
+	BeforeEach(func(ctx SpecContext) {
+		*machinePoolNs = corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "testns-pools-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, machinePoolNs)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, machinePoolNs)
+
+		(*cp).(*Cloud).machinePoolNamespace = machinePoolNs.Name
+	})
+
+	return ns, machinePoolNs, cp, network, clusterName
+}